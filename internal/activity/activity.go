@@ -0,0 +1,41 @@
+// Package activity simulates minimal, unobtrusive user input so the host
+// does not lock its screen or go to sleep.
+package activity
+
+import "context"
+
+// Strategy is one technique for telling the OS the user is still active.
+// Different strategies trade off reliability, detectability, and
+// requirements (e.g. a helper binary) differently, which is why keep-alive
+// supports picking one explicitly or cycling through all of them.
+type Strategy interface {
+	// Name identifies the strategy for --strategy and log output.
+	Name() string
+	// Available reports whether this strategy can run in the current
+	// environment (e.g. whether a required helper binary is installed).
+	Available() bool
+	// Simulate performs one unit of synthetic activity.
+	Simulate(ctx context.Context) error
+}
+
+// Strategies returns every Strategy registered for the current platform, in
+// a stable order.
+func Strategies() []Strategy {
+	return strategies()
+}
+
+// ByName returns the registered strategy with the given name, or nil if
+// there isn't one.
+func ByName(name string) Strategy {
+	for _, s := range Strategies() {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// IdleSeconds returns how long the host has seen no real user input.
+func IdleSeconds() (float64, error) {
+	return idleSeconds()
+}
@@ -0,0 +1,138 @@
+//go:build darwin
+
+package activity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+func strategies() []Strategy {
+	return []Strategy{
+		mouseNudgeStrategy{},
+		keyStrokeStrategy{},
+		&caffeinateStrategy{},
+	}
+}
+
+// mouseNudgeStrategy prefers cliclick (no accessibility permissions
+// required) and falls back to an AppleScript System Events nudge.
+type mouseNudgeStrategy struct{}
+
+func (mouseNudgeStrategy) Name() string    { return "mouse-nudge" }
+func (mouseNudgeStrategy) Available() bool { return true }
+
+func (mouseNudgeStrategy) Simulate(ctx context.Context) error {
+	if _, err := exec.LookPath("cliclick"); err == nil {
+		return exec.Command("cliclick", "m:+1,+1", "w:10", "m:-1,-1").Run()
+	}
+	return appleScriptNudge()
+}
+
+// keyStrokeStrategy presses and releases F15 via cliclick, a key with no
+// default binding on any keyboard layout.
+type keyStrokeStrategy struct{}
+
+func (keyStrokeStrategy) Name() string { return "key-stroke" }
+
+func (keyStrokeStrategy) Available() bool {
+	_, err := exec.LookPath("cliclick")
+	return err == nil
+}
+
+func (keyStrokeStrategy) Simulate(ctx context.Context) error {
+	// kd:/ku: only accept modifier keys (cmd/ctrl/alt/shift/fn); f15 is a
+	// regular key, so tapping it goes through kp: instead.
+	if err := exec.Command("cliclick", "kp:f15").Run(); err != nil {
+		return fmt.Errorf("cliclick kp:f15: %w", err)
+	}
+	return nil
+}
+
+// caffeinateStrategy holds a `caffeinate -disu` assertion for this
+// process's lifetime rather than poking the system every tick; Simulate
+// just makes sure the assertion is still running. alive is updated from a
+// Wait() goroutine, so it's guarded by mu rather than read off cmd directly -
+// ProcessState never becomes non-nil without a Wait() call, which would
+// otherwise leave the dead process unreaped as a zombie forever.
+type caffeinateStrategy struct {
+	mu    sync.Mutex
+	cmd   *exec.Cmd
+	alive bool
+}
+
+func (*caffeinateStrategy) Name() string { return "caffeinate" }
+
+func (*caffeinateStrategy) Available() bool {
+	_, err := exec.LookPath("caffeinate")
+	return err == nil
+}
+
+func (s *caffeinateStrategy) Simulate(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.alive {
+		return nil // assertion already held
+	}
+
+	cmd := exec.Command("caffeinate", "-disu", "-w", strconv.Itoa(os.Getpid()))
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	s.cmd = cmd
+	s.alive = true
+
+	go func() {
+		cmd.Wait()
+		s.mu.Lock()
+		s.alive = false
+		s.mu.Unlock()
+	}()
+
+	return nil
+}
+
+func appleScriptNudge() error {
+	script := `
+		tell application "System Events"
+			set currentPos to (get position of mouse)
+			set mouseX to item 1 of currentPos
+			set mouseY to item 2 of currentPos
+			set mouse position to {mouseX + 1, mouseY + 1}
+			delay 0.01
+			set mouse position to {mouseX, mouseY}
+		end tell
+	`
+	if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+		return fmt.Errorf("osascript: %w", err)
+	}
+	return nil
+}
+
+var idleTimeRe = regexp.MustCompile(`"HIDIdleTime"\s*=\s*(\d+)`)
+
+// idleSeconds shells out to ioreg for the HID idle time (in nanoseconds)
+// rather than calling CGEventSourceSecondsSinceLastEventType, which would
+// require cgo.
+func idleSeconds() (float64, error) {
+	out, err := exec.Command("ioreg", "-c", "IOHIDSystem").Output()
+	if err != nil {
+		return 0, fmt.Errorf("ioreg: %w", err)
+	}
+
+	m := idleTimeRe.FindSubmatch(out)
+	if m == nil {
+		return 0, fmt.Errorf("HIDIdleTime not found in ioreg output")
+	}
+	ns, err := strconv.ParseFloat(string(m[1]), 64)
+	if err != nil {
+		return 0, err
+	}
+	return ns / 1e9, nil
+}
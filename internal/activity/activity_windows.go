@@ -0,0 +1,188 @@
+//go:build windows
+
+package activity
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	inputMouse    = 0
+	inputKeyboard = 1
+
+	mouseEventFMove = 0x0001
+
+	keyEventFKeyUp = 0x0002
+	vkF15          = 0x7E
+
+	esContinuous      = 0x80000000
+	esDisplayRequired = 0x00000002
+)
+
+var (
+	user32   = syscall.NewLazyDLL("user32.dll")
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procSendInput               = user32.NewProc("SendInput")
+	procGetCursorPos            = user32.NewProc("GetCursorPos")
+	procSetCursorPos            = user32.NewProc("SetCursorPos")
+	procSetThreadExecutionState = kernel32.NewProc("SetThreadExecutionState")
+	procGetLastInputInfo        = user32.NewProc("GetLastInputInfo")
+	procGetTickCount            = kernel32.NewProc("GetTickCount")
+)
+
+// point mirrors the Win32 POINT struct.
+type point struct {
+	X, Y int32
+}
+
+// mouseInput mirrors the Win32 MOUSEINPUT struct.
+type mouseInput struct {
+	Dx, Dy      int32
+	MouseData   uint32
+	DwFlags     uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+// mouseEvent mirrors the Win32 INPUT struct for an INPUT_MOUSE event. Go has
+// no union type, so the struct is exactly as large as the MOUSEINPUT
+// variant, the largest member of the real union.
+type mouseEvent struct {
+	Type uint32
+	Mi   mouseInput
+}
+
+// keybdInput mirrors the Win32 KEYBDINPUT struct.
+type keybdInput struct {
+	WVk         uint16
+	WScan       uint16
+	DwFlags     uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+// keybdEvent mirrors the Win32 INPUT struct for an INPUT_KEYBOARD event. The
+// trailing padding pads KEYBDINPUT out to the same size as the MOUSEINPUT
+// variant above, since SendInput expects every INPUT in the array to share
+// one cbSize regardless of which union member is populated.
+type keybdEvent struct {
+	Type uint32
+	Ki   keybdInput
+	_    [8]byte
+}
+
+// lastInputInfo mirrors the Win32 LASTINPUTINFO struct.
+type lastInputInfo struct {
+	CbSize uint32
+	DwTime uint32
+}
+
+func strategies() []Strategy {
+	return []Strategy{
+		mouseNudgeStrategy{},
+		keyStrokeStrategy{},
+		executionStateStrategy{},
+	}
+}
+
+// mouseNudgeStrategy sends a relative, zero-distance INPUT_MOUSE event via
+// SendInput, falling back to the GetCursorPos/SetCursorPos dance this
+// codebase used before SendInput existed, for the rare case SendInput
+// itself is blocked (e.g. by UIPI on a locked-down session).
+type mouseNudgeStrategy struct{}
+
+func (mouseNudgeStrategy) Name() string    { return "mouse-nudge" }
+func (mouseNudgeStrategy) Available() bool { return true }
+
+func (mouseNudgeStrategy) Simulate(ctx context.Context) error {
+	if err := sendMouseMove(); err == nil {
+		return nil
+	}
+	return nudgeCursorPos()
+}
+
+// keyStrokeStrategy presses and releases VK_F15, a key with no default
+// binding on any keyboard layout - the same technique real accessibility
+// tools use to hold a session open without generating visible input.
+type keyStrokeStrategy struct{}
+
+func (keyStrokeStrategy) Name() string    { return "key-stroke" }
+func (keyStrokeStrategy) Available() bool { return true }
+
+func (keyStrokeStrategy) Simulate(ctx context.Context) error {
+	down := keybdEvent{Type: inputKeyboard, Ki: keybdInput{WVk: vkF15}}
+	up := keybdEvent{Type: inputKeyboard, Ki: keybdInput{WVk: vkF15, DwFlags: keyEventFKeyUp}}
+	if err := sendInput(unsafe.Pointer(&down), unsafe.Sizeof(down)); err != nil {
+		return err
+	}
+	return sendInput(unsafe.Pointer(&up), unsafe.Sizeof(up))
+}
+
+// executionStateStrategy tells the power manager directly that the display
+// should stay on, via SetThreadExecutionState, instead of faking input. The
+// flag is cleared the moment another thread calls SetThreadExecutionState
+// without ES_CONTINUOUS (or the process exits), so this needs to be
+// reasserted on every tick.
+type executionStateStrategy struct{}
+
+func (executionStateStrategy) Name() string    { return "execution-state" }
+func (executionStateStrategy) Available() bool { return true }
+
+func (executionStateStrategy) Simulate(ctx context.Context) error {
+	ret, _, err := procSetThreadExecutionState.Call(uintptr(esContinuous | esDisplayRequired))
+	if ret == 0 {
+		return fmt.Errorf("SetThreadExecutionState: %w", err)
+	}
+	return nil
+}
+
+func sendMouseMove() error {
+	ev := mouseEvent{
+		Type: inputMouse,
+		Mi: mouseInput{
+			DwFlags: mouseEventFMove,
+		},
+	}
+	return sendInput(unsafe.Pointer(&ev), unsafe.Sizeof(ev))
+}
+
+func nudgeCursorPos() error {
+	var pos point
+	ret, _, err := procGetCursorPos.Call(uintptr(unsafe.Pointer(&pos)))
+	if ret == 0 {
+		return fmt.Errorf("GetCursorPos: %w", err)
+	}
+	ret, _, err = procSetCursorPos.Call(uintptr(pos.X+1), uintptr(pos.Y))
+	if ret == 0 {
+		return fmt.Errorf("SetCursorPos: %w", err)
+	}
+	ret, _, err = procSetCursorPos.Call(uintptr(pos.X), uintptr(pos.Y))
+	if ret == 0 {
+		return fmt.Errorf("SetCursorPos: %w", err)
+	}
+	return nil
+}
+
+func sendInput(ev unsafe.Pointer, size uintptr) error {
+	ret, _, err := procSendInput.Call(1, uintptr(ev), size)
+	if ret == 0 {
+		return fmt.Errorf("SendInput: %w", err)
+	}
+	return nil
+}
+
+// idleSeconds reports how long it has been since GetLastInputInfo saw any
+// keyboard or mouse input anywhere in the session.
+func idleSeconds() (float64, error) {
+	info := lastInputInfo{CbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	ret, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return 0, fmt.Errorf("GetLastInputInfo: %w", err)
+	}
+	tick, _, _ := procGetTickCount.Call()
+	return float64(uint32(tick)-info.DwTime) / 1000, nil
+}
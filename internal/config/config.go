@@ -0,0 +1,189 @@
+// Package config loads keep-alive's optional settings file: a small TOML
+// subset with a default section plus named [profiles.*] overrides, so users
+// can keep a "work" profile and a "presentation" profile without wrapping
+// the binary in shell scripts.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Section holds the settings a profile can override. Fields are left as the
+// raw flag-style strings (e.g. Interval is "45s", not a time.Duration) so
+// the same parsing main.go already does for its flags applies uniformly
+// whether a value came from the command line or the config file.
+type Section struct {
+	Interval   string
+	Jitter     string
+	Strategy   string
+	Schedule   string
+	QuietHours string
+}
+
+// Config is the parsed config file: a default Section plus any number of
+// named profiles that override it.
+type Config struct {
+	Section
+	Profiles map[string]Section
+}
+
+// Resolve returns the effective Section for the given profile name, with
+// fields set in that profile overriding the top-level defaults. An empty
+// name, or a name with no matching profile, returns the defaults unchanged.
+func (c *Config) Resolve(profile string) Section {
+	s := c.Section
+	if profile == "" {
+		return s
+	}
+	p, ok := c.Profiles[profile]
+	if !ok {
+		return s
+	}
+	if p.Interval != "" {
+		s.Interval = p.Interval
+	}
+	if p.Jitter != "" {
+		s.Jitter = p.Jitter
+	}
+	if p.Strategy != "" {
+		s.Strategy = p.Strategy
+	}
+	if p.Schedule != "" {
+		s.Schedule = p.Schedule
+	}
+	if p.QuietHours != "" {
+		s.QuietHours = p.QuietHours
+	}
+	return s
+}
+
+// DefaultPath returns the platform-conventional location for config.toml:
+// %APPDATA%\keep-alive\config.toml on Windows, $XDG_CONFIG_HOME/keep-alive
+// (or ~/.config/keep-alive) everywhere else.
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "keep-alive", "config.toml")
+		}
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "keep-alive", "config.toml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "keep-alive", "config.toml")
+}
+
+// Load parses the config file at path. A missing file is not an error: it
+// returns an empty Config so callers can fall back to their own defaults and
+// log a line, the same "soft error" treatment keep-alive gives a missing
+// resume-notification API. Load itself logs nothing and returns the same
+// empty Config as for an empty file, so a caller that logs checks for the
+// file itself.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{Profiles: map[string]Section{}}, nil
+		}
+		return nil, fmt.Errorf("open config: %w", err)
+	}
+	defer f.Close()
+
+	cfg := &Config{Profiles: map[string]Section{}}
+	profiles := map[string]*Section{}
+	cur := &cfg.Section
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			name := strings.TrimSpace(strings.Trim(line, "[]"))
+			profile := strings.TrimPrefix(name, "profiles.")
+			if profile == name {
+				return nil, fmt.Errorf("config line %d: unknown section %q (want [profiles.<name>])", lineNo, name)
+			}
+			s := &Section{}
+			profiles[profile] = s
+			cur = s
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("config line %d: expected key = value", lineNo)
+		}
+		if err := cur.set(strings.TrimSpace(key), unquote(strings.TrimSpace(value))); err != nil {
+			return nil, fmt.Errorf("config line %d: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	for name, s := range profiles {
+		cfg.Profiles[name] = *s
+	}
+	return cfg, nil
+}
+
+func (s *Section) set(key, value string) error {
+	switch key {
+	case "interval":
+		s.Interval = value
+	case "jitter":
+		s.Jitter = value
+	case "strategy":
+		s.Strategy = value
+	case "schedule":
+		s.Schedule = value
+	case "quiet_hours":
+		s.QuietHours = value
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// unquote strips a quoted string's surrounding quotes, or trims a trailing
+// "# ..." comment off a bare value. This is deliberately a small subset of
+// TOML: no arrays, tables-of-tables, or multiline strings - just what
+// interval/jitter/strategy/schedule/quiet_hours need.
+func unquote(v string) string {
+	if strings.HasPrefix(v, `"`) {
+		if j := strings.LastIndex(v, `"`); j > 0 {
+			return v[1:j]
+		}
+	}
+	if i := strings.Index(v, "#"); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
+
+// ReloadWatcher notifies the caller when the config file on disk may have
+// changed, so it can be reloaded without restarting the process.
+type ReloadWatcher interface {
+	// Events fires whenever the config file should be re-read.
+	Events() <-chan struct{}
+	Close() error
+}
+
+// NewReloadWatcher starts watching path for changes: SIGHUP on macOS, a
+// modification-time poll on Windows.
+func NewReloadWatcher(path string) (ReloadWatcher, error) {
+	return newReloadWatcher(path)
+}
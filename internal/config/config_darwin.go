@@ -0,0 +1,50 @@
+//go:build darwin
+
+package config
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// darwinReloadWatcher reloads on SIGHUP, the conventional "re-read your
+// config" signal for long-running Unix daemons.
+type darwinReloadWatcher struct {
+	sigChan chan os.Signal
+	events  chan struct{}
+	done    chan struct{}
+}
+
+func newReloadWatcher(path string) (ReloadWatcher, error) {
+	w := &darwinReloadWatcher{
+		sigChan: make(chan os.Signal, 1),
+		events:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	signal.Notify(w.sigChan, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-w.sigChan:
+				select {
+				case w.events <- struct{}{}:
+				default:
+				}
+			case <-w.done:
+				return
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+func (w *darwinReloadWatcher) Events() <-chan struct{} { return w.events }
+
+func (w *darwinReloadWatcher) Close() error {
+	signal.Stop(w.sigChan)
+	close(w.done)
+	return nil
+}
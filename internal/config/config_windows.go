@@ -0,0 +1,64 @@
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const pollInterval = 5 * time.Second
+
+// windowsReloadWatcher polls the config file's modification time. Windows
+// has no SIGHUP equivalent, and a ReadDirectoryChangesW or named-pipe
+// watcher would pull in a meaningful amount of new syscall surface for a
+// rarely-used feature, so a slow poll is the pragmatic tradeoff here.
+type windowsReloadWatcher struct {
+	events chan struct{}
+	done   chan struct{}
+}
+
+func newReloadWatcher(path string) (ReloadWatcher, error) {
+	w := &windowsReloadWatcher{
+		events: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		var lastMod time.Time
+		if info, err := os.Stat(path); err == nil {
+			lastMod = info.ModTime()
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil {
+					continue
+				}
+				if info.ModTime().After(lastMod) {
+					lastMod = info.ModTime()
+					select {
+					case w.events <- struct{}{}:
+					default:
+					}
+				}
+			case <-w.done:
+				return
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+func (w *windowsReloadWatcher) Events() <-chan struct{} { return w.events }
+
+func (w *windowsReloadWatcher) Close() error {
+	close(w.done)
+	return nil
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdayIndex = map[string]time.Weekday{
+	"Sun": time.Sunday, "Mon": time.Monday, "Tue": time.Tuesday, "Wed": time.Wednesday,
+	"Thu": time.Thursday, "Fri": time.Friday, "Sat": time.Saturday,
+}
+
+// Schedule is an active window like "Mon-Fri 09:00-17:00": keep-alive only
+// runs its strategies while the current time falls inside it.
+type Schedule struct {
+	days             [7]bool // indexed by time.Weekday
+	startMin, endMin int
+}
+
+// ParseSchedule parses a "<days> <start>-<end>" window, e.g.
+// "Mon-Fri 09:00-17:00" or "Sat 10:00-14:00". An empty string means no
+// schedule restriction, reported as a nil *Schedule.
+func ParseSchedule(s string) (*Schedule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf(`invalid schedule %q: want "<days> <start>-<end>"`, s)
+	}
+
+	days, err := parseDays(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	start, end, err := parseTimeRange(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+
+	sch := &Schedule{startMin: start, endMin: end}
+	for _, d := range days {
+		sch.days[d] = true
+	}
+	return sch, nil
+}
+
+// Active reports whether t falls inside the schedule's day and time window.
+// A nil Schedule is always active.
+func (s *Schedule) Active(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	min := t.Hour()*60 + t.Minute()
+	if s.startMin <= s.endMin {
+		return s.days[t.Weekday()] && minuteInRange(min, s.startMin, s.endMin)
+	}
+
+	// The window wraps past midnight, so it spans two calendar days: the
+	// late-night part (>= startMin) belongs to today's scheduled day, and
+	// the early-morning part (< endMin) is the tail end of *yesterday's*
+	// window, so it's yesterday's day that has to be in days, not today's.
+	if min >= s.startMin {
+		return s.days[t.Weekday()]
+	}
+	if min < s.endMin {
+		return s.days[t.Add(-24*time.Hour).Weekday()]
+	}
+	return false
+}
+
+// QuietHours is a daily time-of-day window, e.g. "22:00-07:00", during which
+// keep-alive skips its ticks regardless of Schedule.
+type QuietHours struct {
+	startMin, endMin int
+}
+
+// ParseQuietHours parses a "<start>-<end>" window. An empty string means no
+// quiet hours, reported as a nil *QuietHours.
+func ParseQuietHours(s string) (*QuietHours, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	start, end, err := parseTimeRange(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours %q: %w", s, err)
+	}
+	return &QuietHours{startMin: start, endMin: end}, nil
+}
+
+// Active reports whether t falls inside the quiet hours window. A nil
+// QuietHours is never active.
+func (q *QuietHours) Active(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+	return minuteInRange(t.Hour()*60+t.Minute(), q.startMin, q.endMin)
+}
+
+func parseDays(s string) ([]time.Weekday, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		d, err := parseDay(s)
+		if err != nil {
+			return nil, err
+		}
+		return []time.Weekday{d}, nil
+	}
+
+	start, err := parseDay(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseDay(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []time.Weekday
+	for d := start; ; d = (d + 1) % 7 {
+		days = append(days, d)
+		if d == end {
+			break
+		}
+	}
+	return days, nil
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	d, ok := weekdayIndex[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown day %q (want Sun, Mon, Tue, ...)", s)
+	}
+	return d, nil
+}
+
+// parseTimeRange parses a "<start>-<end>" clock range, e.g. "09:00-17:00".
+// end < start is valid and means the window wraps past midnight.
+func parseTimeRange(s string) (start, end int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf(`want "<start>-<end>", got %q`, s)
+	}
+	start, err = parseClock(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err = parseClock(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time %q: want HH:MM", s)
+	}
+	hh, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	mm, err := strconv.Atoi(m)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return hh*60 + mm, nil
+}
+
+func minuteInRange(min, start, end int) bool {
+	if start <= end {
+		return min >= start && min < end
+	}
+	return min >= start || min < end // wraps past midnight
+}
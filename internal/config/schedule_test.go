@@ -0,0 +1,181 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseSchedule(t *testing.T, s string) *Schedule {
+	t.Helper()
+	sch, err := ParseSchedule(s)
+	if err != nil {
+		t.Fatalf("ParseSchedule(%q): %v", s, err)
+	}
+	return sch
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	cases := []string{
+		"Mon-Fri",
+		"Mon-Fri 09:00",
+		"Xyz 09:00-17:00",
+		"Mon 0900-1700",
+		"Mon 09:00",
+	}
+	for _, s := range cases {
+		if _, err := ParseSchedule(s); err == nil {
+			t.Errorf("ParseSchedule(%q): expected error, got nil", s)
+		}
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule string
+		at       time.Time
+		want     bool
+	}{
+		{
+			name:     "inside a weekday range and time window",
+			schedule: "Mon-Fri 09:00-17:00",
+			at:       time.Date(2026, 7, 29, 10, 30, 0, 0, time.UTC), // Wednesday
+			want:     true,
+		},
+		{
+			name:     "outside the time window on an active day",
+			schedule: "Mon-Fri 09:00-17:00",
+			at:       time.Date(2026, 7, 29, 18, 0, 0, 0, time.UTC), // Wednesday
+			want:     false,
+		},
+		{
+			name:     "on a day outside the range",
+			schedule: "Mon-Fri 09:00-17:00",
+			at:       time.Date(2026, 8, 1, 10, 0, 0, 0, time.UTC), // Saturday
+			want:     false,
+		},
+		{
+			name:     "single day, not a range",
+			schedule: "Sat 10:00-14:00",
+			at:       time.Date(2026, 8, 1, 11, 0, 0, 0, time.UTC), // Saturday
+			want:     true,
+		},
+		{
+			name:     "window wrapping past midnight, before midnight",
+			schedule: "Mon-Sun 22:00-02:00",
+			at:       time.Date(2026, 7, 29, 23, 0, 0, 0, time.UTC), // Wednesday
+			want:     true,
+		},
+		{
+			name:     "window wrapping past midnight, after midnight",
+			schedule: "Mon-Sun 22:00-02:00",
+			at:       time.Date(2026, 7, 29, 1, 0, 0, 0, time.UTC), // Wednesday
+			want:     true,
+		},
+		{
+			name:     "window wrapping past midnight, outside it",
+			schedule: "Mon-Sun 22:00-02:00",
+			at:       time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC), // Wednesday
+			want:     false,
+		},
+		{
+			name:     "range wrapping the week, Fri-Mon",
+			schedule: "Fri-Mon 09:00-17:00",
+			at:       time.Date(2026, 8, 1, 10, 0, 0, 0, time.UTC), // Saturday
+			want:     true,
+		},
+		{
+			name:     "single day wrapping midnight, still that day's late-night part",
+			schedule: "Mon 22:00-02:00",
+			at:       time.Date(2026, 7, 27, 23, 0, 0, 0, time.UTC), // Monday 23:00
+			want:     true,
+		},
+		{
+			name:     "single day wrapping midnight, carries into the next calendar day",
+			schedule: "Mon 22:00-02:00",
+			at:       time.Date(2026, 7, 28, 1, 0, 0, 0, time.UTC), // Tuesday 01:00, still "Monday night"
+			want:     true,
+		},
+		{
+			name:     "single day wrapping midnight, the day after doesn't also get a late-night window",
+			schedule: "Mon 22:00-02:00",
+			at:       time.Date(2026, 7, 28, 23, 0, 0, 0, time.UTC), // Tuesday 23:00
+			want:     false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sch := mustParseSchedule(t, c.schedule)
+			if got := sch.Active(c.at); got != c.want {
+				t.Errorf("Active(%v) = %v, want %v", c.at, got, c.want)
+			}
+		})
+	}
+}
+
+func TestScheduleActiveNilIsAlwaysActive(t *testing.T) {
+	var sch *Schedule
+	if !sch.Active(time.Now()) {
+		t.Error("nil Schedule should always be active")
+	}
+}
+
+func TestParseQuietHoursErrors(t *testing.T) {
+	if _, err := ParseQuietHours("22:00"); err == nil {
+		t.Error("expected error for missing end time")
+	}
+}
+
+func TestQuietHoursActive(t *testing.T) {
+	cases := []struct {
+		name string
+		spec string
+		at   time.Time
+		want bool
+	}{
+		{
+			name: "inside window before midnight",
+			spec: "22:00-07:00",
+			at:   time.Date(2026, 7, 29, 23, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "inside window after midnight",
+			spec: "22:00-07:00",
+			at:   time.Date(2026, 7, 29, 5, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "outside window",
+			spec: "22:00-07:00",
+			at:   time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "non-wrapping window",
+			spec: "12:00-13:00",
+			at:   time.Date(2026, 7, 29, 12, 30, 0, 0, time.UTC),
+			want: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			qh, err := ParseQuietHours(c.spec)
+			if err != nil {
+				t.Fatalf("ParseQuietHours(%q): %v", c.spec, err)
+			}
+			if got := qh.Active(c.at); got != c.want {
+				t.Errorf("Active(%v) = %v, want %v", c.at, got, c.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursActiveNilIsNeverActive(t *testing.T) {
+	var qh *QuietHours
+	if qh.Active(time.Now()) {
+		t.Error("nil QuietHours should never be active")
+	}
+}
@@ -0,0 +1,26 @@
+// Package power watches for the host suspending and resuming, so callers
+// can react immediately on wake instead of waiting out a timer that was set
+// before the sleep (a plain time.Ticker doesn't account for suspended time,
+// so a 30s ticker armed before a two-hour sleep won't fire until two hours
+// after wake).
+package power
+
+// Event describes a host power-state transition.
+type Event int
+
+const (
+	Suspend Event = iota
+	Resume
+)
+
+// Watcher delivers suspend/resume transitions on Events until Close is
+// called.
+type Watcher interface {
+	Events() <-chan Event
+	Close() error
+}
+
+// NewWatcher starts watching for suspend/resume on the current platform.
+func NewWatcher() (Watcher, error) {
+	return newWatcher()
+}
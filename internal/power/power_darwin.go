@@ -0,0 +1,89 @@
+//go:build darwin
+
+package power
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const pollInterval = 30 * time.Second
+
+// darwinWatcher polls the unified log for a recent wake entry. This trades
+// prompt detection (bounded by pollInterval) for staying pure Go: subscribing
+// to NSWorkspaceDidWakeNotification directly would require an Objective-C
+// shim and cgo, which this codebase otherwise avoids. Only Resume events are
+// delivered; suspend is not detected on macOS.
+type darwinWatcher struct {
+	events chan Event
+	stop   chan struct{}
+}
+
+func newWatcher() (Watcher, error) {
+	w := &darwinWatcher{events: make(chan Event, 4), stop: make(chan struct{})}
+	go w.poll()
+	return w, nil
+}
+
+func (w *darwinWatcher) Events() <-chan Event {
+	return w.events
+}
+
+func (w *darwinWatcher) Close() error {
+	close(w.stop)
+	return nil
+}
+
+func (w *darwinWatcher) poll() {
+	// Any wake already in the log at startup happened before we were
+	// watching; record it so only later wakes are reported.
+	lastWake, _ := latestWakeEntry()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			wake, err := latestWakeEntry()
+			if err != nil || wake == "" || wake == lastWake {
+				continue
+			}
+			select {
+			case w.events <- Resume:
+			default:
+			}
+			lastWake = wake
+		}
+	}
+}
+
+// latestWakeEntry returns the most recent "Wake" line logged within the
+// last two minutes (a few poll intervals), or "" if none is found. `pmset -g log` dumps the
+// machine's entire power-management history on every call - hundreds of KB
+// to MB - which this codebase used to re-exec and re-scan on every poll;
+// `log show --last` instead asks the unified log for only the recent
+// window, so each poll's cost stays bounded regardless of uptime.
+func latestWakeEntry() (string, error) {
+	out, err := exec.Command("log", "show",
+		"--style", "compact",
+		"--last", "2m",
+		"--predicate", `eventMessage CONTAINS "Wake"`,
+	).Output()
+	if err != nil {
+		return "", err
+	}
+
+	var last string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, "Wake") {
+			last = line
+		}
+	}
+	return last, nil
+}
@@ -0,0 +1,95 @@
+//go:build windows
+
+package power
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	deviceNotifyCallback = 2
+
+	pbtAPMSuspend         = 0x0004
+	pbtAPMResumeSuspend   = 0x0007
+	pbtAPMResumeAutomatic = 0x0012
+)
+
+var (
+	kernel32                                     = syscall.NewLazyDLL("kernel32.dll")
+	procPowerRegisterSuspendResumeNotification   = kernel32.NewProc("PowerRegisterSuspendResumeNotification")
+	procPowerUnregisterSuspendResumeNotification = kernel32.NewProc("PowerUnregisterSuspendResumeNotification")
+	notifyCallback                               = syscall.NewCallback(notifyProc)
+)
+
+// deviceNotifySubscribeParameters mirrors the Win32
+// DEVICE_NOTIFY_SUBSCRIBE_PARAMETERS struct.
+type deviceNotifySubscribeParameters struct {
+	Callback uintptr
+	Context  uintptr
+}
+
+type windowsWatcher struct {
+	handle uintptr
+	events chan Event
+}
+
+// activeWatcher is valid for as long as one windowsWatcher is registered.
+// Windows calls notifyProc on its own thread with only a Context pointer we
+// control, so this mirrors the single-context pattern in internal/service.
+var activeWatcher *windowsWatcher
+
+func newWatcher() (Watcher, error) {
+	w := &windowsWatcher{events: make(chan Event, 4)}
+	activeWatcher = w
+
+	params := deviceNotifySubscribeParameters{Callback: notifyCallback}
+	// Unlike most user32/advapi32 calls in this codebase, this function
+	// returns ERROR_SUCCESS (0) on success and a Win32 error code on
+	// failure, not a BOOL.
+	ret, _, _ := procPowerRegisterSuspendResumeNotification.Call(
+		uintptr(deviceNotifyCallback),
+		uintptr(unsafe.Pointer(&params)),
+		uintptr(unsafe.Pointer(&w.handle)),
+	)
+	if ret != 0 {
+		activeWatcher = nil
+		return nil, fmt.Errorf("PowerRegisterSuspendResumeNotification: error code %d", ret)
+	}
+	return w, nil
+}
+
+func (w *windowsWatcher) Events() <-chan Event {
+	return w.events
+}
+
+func (w *windowsWatcher) Close() error {
+	ret, _, _ := procPowerUnregisterSuspendResumeNotification.Call(w.handle)
+	activeWatcher = nil
+	if ret != 0 {
+		return fmt.Errorf("PowerUnregisterSuspendResumeNotification: error code %d", ret)
+	}
+	return nil
+}
+
+func notifyProc(context uintptr, eventType uint32, setting uintptr) uintptr {
+	w := activeWatcher
+	if w == nil {
+		return 0
+	}
+	var ev Event
+	switch eventType {
+	case pbtAPMSuspend:
+		ev = Suspend
+	case pbtAPMResumeAutomatic, pbtAPMResumeSuspend:
+		ev = Resume
+	default:
+		return 0
+	}
+	select {
+	case w.events <- ev:
+	default:
+	}
+	return 0
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// Logger writes keep-alive's operational log: to stdout when running
+// interactively, or to the platform's service log (Windows Event Log, a
+// file under ~/Library/Logs) when running under a service manager with no
+// console attached.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// NewLogger returns a console logger when interactive is true, or the
+// platform's non-interactive logger otherwise.
+func NewLogger(cfg Config, interactive bool) Logger {
+	if interactive {
+		return consoleLogger{}
+	}
+	return newPlatformLogger(cfg)
+}
+
+type consoleLogger struct{}
+
+func (consoleLogger) Printf(format string, args ...interface{}) {
+	fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,46 @@
+// Package service lets keep-alive register itself as a Windows Service or a
+// macOS launchd LaunchAgent, so it can run unattended at login without a
+// visible console window.
+package service
+
+// Program is the long-running work a Manager starts and stops on the
+// caller's behalf. Start must return quickly; it should launch the real work
+// on its own goroutine. Stop is called exactly once and must block until
+// that goroutine has exited cleanly.
+type Program interface {
+	Start() error
+	Stop() error
+}
+
+// Config describes how the service is registered with the OS.
+type Config struct {
+	Name        string
+	DisplayName string
+	Description string
+}
+
+// Manager installs, removes, and runs a Program as a background service.
+type Manager interface {
+	Install() error
+	Uninstall() error
+	Start() error
+	Stop() error
+
+	// Run hands control to the OS service manager (Windows SCM, launchd).
+	// It blocks until the OS asks the service to exit, driving p.Start and
+	// p.Stop at the right times. When the process is not running under a
+	// service manager, Run falls back to driving p directly, blocking until
+	// the process receives SIGINT or SIGTERM and then calling p.Stop.
+	Run(p Program) error
+}
+
+// New returns the Manager for the current platform.
+func New(cfg Config) Manager {
+	return newManager(cfg)
+}
+
+// Interactive reports whether this process is attached to a console/TTY, as
+// opposed to being launched non-interactively by the OS's service manager.
+func Interactive() bool {
+	return isInteractive()
+}
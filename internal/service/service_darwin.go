@@ -0,0 +1,164 @@
+//go:build darwin
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"text/template"
+	"time"
+)
+
+type darwinManager struct {
+	cfg Config
+}
+
+func newManager(cfg Config) Manager {
+	return &darwinManager{cfg: cfg}
+}
+
+func (m *darwinManager) label() string {
+	return "com.ternarybob." + m.cfg.Name
+}
+
+func (m *darwinManager) plistPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", m.label()+".plist"), nil
+}
+
+func (m *darwinManager) Install() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable path: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	logDir := filepath.Join(home, "Library", "Logs")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+
+	path, err := m.plistPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create LaunchAgents directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := plistTemplate.Execute(&buf, plistData{
+		Label:      m.label(),
+		Executable: exe,
+		LogPath:    filepath.Join(logDir, m.cfg.Name+".log"),
+	}); err != nil {
+		return fmt.Errorf("render launchd plist: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write launchd plist: %w", err)
+	}
+
+	return exec.Command("launchctl", "load", "-w", path).Run()
+}
+
+func (m *darwinManager) Uninstall() error {
+	path, err := m.plistPath()
+	if err != nil {
+		return err
+	}
+	// Best-effort: unload before removing the plist even if it's already
+	// unloaded.
+	_ = exec.Command("launchctl", "unload", "-w", path).Run()
+	return os.Remove(path)
+}
+
+func (m *darwinManager) Start() error {
+	return exec.Command("launchctl", "start", m.label()).Run()
+}
+
+func (m *darwinManager) Stop() error {
+	return exec.Command("launchctl", "stop", m.label()).Run()
+}
+
+// Run starts the program directly and blocks until launchd stops us (it
+// sends SIGTERM on `launchctl stop`/`unload`) or we receive an interrupt.
+func (m *darwinManager) Run(p Program) error {
+	if err := p.Start(); err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	return p.Stop()
+}
+
+func isInteractive() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+type darwinFileLogger struct {
+	path string
+}
+
+func newPlatformLogger(cfg Config) Logger {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return consoleLogger{}
+	}
+	return &darwinFileLogger{path: filepath.Join(home, "Library", "Logs", cfg.Name+".log")}
+}
+
+func (l *darwinFileLogger) Printf(format string, args ...interface{}) {
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "[%s] %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
+
+type plistData struct {
+	Label      string
+	Executable string
+	LogPath    string
+}
+
+var plistTemplate = template.Must(template.New("plist").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{.Executable}}</string>
+		<string>--service</string>
+		<string>run</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>{{.LogPath}}</string>
+	<key>StandardErrorPath</key>
+	<string>{{.LogPath}}</string>
+</dict>
+</plist>
+`))
@@ -0,0 +1,345 @@
+//go:build windows
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	scManagerAllAccess = 0xF003F
+	serviceAllAccess   = 0xF01FF
+
+	serviceWin32OwnProcess = 0x00000010
+	serviceAutoStart       = 0x00000002
+	serviceErrorNormal     = 0x00000001
+
+	serviceControlStop = 0x00000001
+
+	serviceStopped     = 0x00000001
+	serviceRunning     = 0x00000004
+	serviceStopPending = 0x00000003
+
+	serviceAcceptStop = 0x00000001
+)
+
+var (
+	advapi32 = syscall.NewLazyDLL("advapi32.dll")
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procOpenSCManagerW                = advapi32.NewProc("OpenSCManagerW")
+	procCreateServiceW                = advapi32.NewProc("CreateServiceW")
+	procOpenServiceW                  = advapi32.NewProc("OpenServiceW")
+	procDeleteService                 = advapi32.NewProc("DeleteService")
+	procStartServiceW                 = advapi32.NewProc("StartServiceW")
+	procControlService                = advapi32.NewProc("ControlService")
+	procCloseServiceHandle            = advapi32.NewProc("CloseServiceHandle")
+	procStartServiceCtrlDispatcherW   = advapi32.NewProc("StartServiceCtrlDispatcherW")
+	procRegisterServiceCtrlHandlerExW = advapi32.NewProc("RegisterServiceCtrlHandlerExW")
+	procSetServiceStatus              = advapi32.NewProc("SetServiceStatus")
+	procRegisterEventSourceW          = advapi32.NewProc("RegisterEventSourceW")
+	procReportEventW                  = advapi32.NewProc("ReportEventW")
+	procGetConsoleWindow              = kernel32.NewProc("GetConsoleWindow")
+)
+
+// serviceStatus mirrors the Win32 SERVICE_STATUS struct.
+type serviceStatus struct {
+	DwServiceType             uint32
+	DwCurrentState            uint32
+	DwControlsAccepted        uint32
+	DwWin32ExitCode           uint32
+	DwServiceSpecificExitCode uint32
+	DwCheckPoint              uint32
+	DwWaitHint                uint32
+}
+
+// serviceTableEntry mirrors the Win32 SERVICE_TABLE_ENTRYW struct.
+type serviceTableEntry struct {
+	ServiceName *uint16
+	ServiceProc uintptr
+}
+
+type windowsManager struct {
+	cfg Config
+}
+
+func newManager(cfg Config) Manager {
+	return &windowsManager{cfg: cfg}
+}
+
+func (m *windowsManager) Install() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable path: %w", err)
+	}
+
+	scm, err := openSCManager()
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(scm)
+
+	namePtr, _ := syscall.UTF16PtrFromString(m.cfg.Name)
+	displayPtr, _ := syscall.UTF16PtrFromString(m.cfg.DisplayName)
+	// Quote the path and append "run" so the SCM launches us back into
+	// service mode rather than re-triggering install.
+	binPath, _ := syscall.UTF16PtrFromString(fmt.Sprintf(`"%s" --service run`, exe))
+
+	handle, _, callErr := procCreateServiceW.Call(
+		scm,
+		uintptr(unsafe.Pointer(namePtr)),
+		uintptr(unsafe.Pointer(displayPtr)),
+		uintptr(serviceAllAccess),
+		uintptr(serviceWin32OwnProcess),
+		uintptr(serviceAutoStart),
+		uintptr(serviceErrorNormal),
+		uintptr(unsafe.Pointer(binPath)),
+		0, 0, 0, 0, 0,
+	)
+	if handle == 0 {
+		return fmt.Errorf("CreateService: %w", callErr)
+	}
+	defer closeServiceHandle(handle)
+
+	return nil
+}
+
+func (m *windowsManager) Uninstall() error {
+	scm, err := openSCManager()
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(scm)
+
+	handle, err := openService(scm, m.cfg.Name)
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(handle)
+
+	ret, _, callErr := procDeleteService.Call(handle)
+	if ret == 0 {
+		return fmt.Errorf("DeleteService: %w", callErr)
+	}
+	return nil
+}
+
+func (m *windowsManager) Start() error {
+	scm, err := openSCManager()
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(scm)
+
+	handle, err := openService(scm, m.cfg.Name)
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(handle)
+
+	ret, _, callErr := procStartServiceW.Call(handle, 0, 0)
+	if ret == 0 {
+		return fmt.Errorf("StartService: %w", callErr)
+	}
+	return nil
+}
+
+func (m *windowsManager) Stop() error {
+	scm, err := openSCManager()
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(scm)
+
+	handle, err := openService(scm, m.cfg.Name)
+	if err != nil {
+		return err
+	}
+	defer closeServiceHandle(handle)
+
+	var status serviceStatus
+	ret, _, callErr := procControlService.Call(handle, uintptr(serviceControlStop), uintptr(unsafe.Pointer(&status)))
+	if ret == 0 {
+		return fmt.Errorf("ControlService: %w", callErr)
+	}
+	return nil
+}
+
+// Run hands control to the Windows Service Control Manager. It blocks for
+// the lifetime of the service, calling p.Start when the SCM starts us and
+// p.Stop when it asks us to stop.
+func (m *windowsManager) Run(p Program) error {
+	if isInteractive() {
+		// Not actually running under the SCM (e.g. `--service run` invoked
+		// by hand from a console); drive the program directly and block
+		// until we're interrupted.
+		if err := p.Start(); err != nil {
+			return err
+		}
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+		return p.Stop()
+	}
+
+	namePtr, err := syscall.UTF16PtrFromString(m.cfg.Name)
+	if err != nil {
+		return err
+	}
+
+	ctx := &serviceContext{cfg: m.cfg, program: p, stopped: make(chan struct{})}
+	activeCtx = ctx
+	defer func() { activeCtx = nil }()
+
+	table := []serviceTableEntry{
+		{ServiceName: namePtr, ServiceProc: serviceMainCallback},
+		{ServiceName: nil, ServiceProc: 0},
+	}
+
+	ret, _, callErr := procStartServiceCtrlDispatcherW.Call(uintptr(unsafe.Pointer(&table[0])))
+	if ret == 0 {
+		return fmt.Errorf("StartServiceCtrlDispatcher: %w", callErr)
+	}
+	return ctx.runErr
+}
+
+// serviceContext carries the running Program across the SCM's callback
+// boundary; StartServiceCtrlDispatcher only gives us a bare C function
+// pointer, so there is nowhere else to stash it.
+type serviceContext struct {
+	cfg          Config
+	program      Program
+	statusHandle uintptr
+	stopped      chan struct{}
+	runErr       error
+}
+
+// activeCtx is valid only while windowsManager.Run is blocked inside
+// StartServiceCtrlDispatcher, which this process calls at most once.
+var activeCtx *serviceContext
+
+var (
+	serviceMainCallback = syscall.NewCallback(serviceMainProc)
+	handlerExCallback   = syscall.NewCallback(handlerExProc)
+)
+
+func serviceMainProc(argc uint32, argv uintptr) uintptr {
+	ctx := activeCtx
+	if ctx == nil {
+		return 0
+	}
+
+	namePtr, _ := syscall.UTF16PtrFromString(ctx.cfg.Name)
+	handle, _, _ := procRegisterServiceCtrlHandlerExW.Call(
+		uintptr(unsafe.Pointer(namePtr)),
+		handlerExCallback,
+		0,
+	)
+	ctx.statusHandle = handle
+	ctx.setStatus(serviceRunning, serviceAcceptStop)
+
+	if err := ctx.program.Start(); err != nil {
+		ctx.runErr = err
+		ctx.setStatus(serviceStopped, 0)
+		return 0
+	}
+
+	<-ctx.stopped
+	ctx.setStatus(serviceStopped, 0)
+	return 0
+}
+
+func handlerExProc(control, eventType uint32, eventData, context uintptr) uintptr {
+	ctx := activeCtx
+	if ctx == nil {
+		return 0
+	}
+	if control == serviceControlStop {
+		ctx.setStatus(serviceStopPending, 0)
+		go func() {
+			ctx.program.Stop()
+			close(ctx.stopped)
+		}()
+	}
+	return 0
+}
+
+func (ctx *serviceContext) setStatus(state, accepted uint32) {
+	status := serviceStatus{
+		DwServiceType:      serviceWin32OwnProcess,
+		DwCurrentState:     state,
+		DwControlsAccepted: accepted,
+	}
+	procSetServiceStatus.Call(ctx.statusHandle, uintptr(unsafe.Pointer(&status)))
+}
+
+func openSCManager() (uintptr, error) {
+	handle, _, err := procOpenSCManagerW.Call(0, 0, uintptr(scManagerAllAccess))
+	if handle == 0 {
+		return 0, fmt.Errorf("OpenSCManager: %w", err)
+	}
+	return handle, nil
+}
+
+func openService(scm uintptr, name string) (uintptr, error) {
+	namePtr, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	handle, _, callErr := procOpenServiceW.Call(scm, uintptr(unsafe.Pointer(namePtr)), uintptr(serviceAllAccess))
+	if handle == 0 {
+		return 0, fmt.Errorf("OpenService: %w", callErr)
+	}
+	return handle, nil
+}
+
+func closeServiceHandle(handle uintptr) {
+	procCloseServiceHandle.Call(handle)
+}
+
+// isInteractive approximates svc.IsAnInteractiveSession without depending on
+// an external package: a service started by the SCM has no console, so
+// GetConsoleWindow returns NULL.
+func isInteractive() bool {
+	hwnd, _, _ := procGetConsoleWindow.Call()
+	return hwnd != 0
+}
+
+type windowsEventLogger struct {
+	handle uintptr
+}
+
+func newPlatformLogger(cfg Config) Logger {
+	namePtr, err := syscall.UTF16PtrFromString(cfg.Name)
+	if err != nil {
+		return consoleLogger{}
+	}
+	handle, _, _ := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(namePtr)))
+	if handle == 0 {
+		return consoleLogger{}
+	}
+	return &windowsEventLogger{handle: handle}
+}
+
+func (l *windowsEventLogger) Printf(format string, args ...interface{}) {
+	const eventLogInformationType = 0x0004
+	msg := fmt.Sprintf(format, args...)
+	strs, err := syscall.UTF16PtrFromString(msg)
+	if err != nil {
+		return
+	}
+	strPtrs := []*uint16{strs}
+	procReportEventW.Call(
+		l.handle,
+		uintptr(eventLogInformationType),
+		0, 0, 0,
+		uintptr(len(strPtrs)),
+		0,
+		uintptr(unsafe.Pointer(&strPtrs[0])),
+		0,
+	)
+}
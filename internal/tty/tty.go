@@ -0,0 +1,49 @@
+// Package tty reads individual keypresses from the terminal without
+// requiring the user to press Enter.
+package tty
+
+import "sync"
+
+// Key is a single keypress read from the terminal.
+type Key rune
+
+// KeyReader reads individual keypresses, cleanly cancellable from another
+// goroutine so the caller can restore the terminal on shutdown.
+type KeyReader interface {
+	// ReadKey blocks until a key is pressed or Cancel is called, in which
+	// case it returns ok=false.
+	ReadKey() (key Key, ok bool)
+
+	// Cancel unblocks any in-flight or future ReadKey call and restores the
+	// terminal to its original mode. Safe to call more than once.
+	Cancel()
+}
+
+// NewKeyReader returns a KeyReader for the current platform. When there is no
+// terminal to read from (no usable /dev/tty on macOS, stdin not a console on
+// Windows; e.g. running under a service manager), it returns a reader
+// whose ReadKey blocks until Cancel is called and never reports a key, so
+// callers can treat hotkeys as simply disabled rather than special-casing
+// this themselves.
+func NewKeyReader() KeyReader {
+	return newKeyReader()
+}
+
+// noopReader is the non-TTY fallback described above.
+type noopReader struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func newNoopReader() *noopReader {
+	return &noopReader{done: make(chan struct{})}
+}
+
+func (r *noopReader) ReadKey() (Key, bool) {
+	<-r.done
+	return 0, false
+}
+
+func (r *noopReader) Cancel() {
+	r.once.Do(func() { close(r.done) })
+}
@@ -0,0 +1,134 @@
+//go:build darwin
+
+package tty
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// darwinKeyReader puts /dev/tty into raw mode (cfmakeraw, with signal
+// generation left on) and reads one byte at a time. Cancellation uses a self-pipe: Cancel writes a byte to a pipe
+// that ReadKey's select also watches, since there's no portable way to
+// interrupt a blocked read(2) on a tty from another goroutine.
+type darwinKeyReader struct {
+	tty         *os.File
+	origTermios syscall.Termios
+	cancelR     *os.File
+	cancelW     *os.File
+	once        sync.Once
+}
+
+func newKeyReader() KeyReader {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDONLY, 0)
+	if err != nil {
+		return newNoopReader()
+	}
+
+	var orig syscall.Termios
+	if err := tcgetattr(tty.Fd(), &orig); err != nil {
+		tty.Close()
+		return newNoopReader()
+	}
+
+	raw := orig
+	cfmakeraw(&raw)
+	// Keep ISIG so Ctrl+C still raises SIGINT for the caller's signal handler.
+	raw.Lflag |= syscall.ISIG
+	if err := tcsetattr(tty.Fd(), &raw); err != nil {
+		tty.Close()
+		return newNoopReader()
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		tcsetattr(tty.Fd(), &orig)
+		tty.Close()
+		return newNoopReader()
+	}
+
+	return &darwinKeyReader{tty: tty, origTermios: orig, cancelR: r, cancelW: w}
+}
+
+func (k *darwinKeyReader) ReadKey() (Key, bool) {
+	ttyFd := int(k.tty.Fd())
+	cancelFd := int(k.cancelR.Fd())
+	maxFd := ttyFd
+	if cancelFd > maxFd {
+		maxFd = cancelFd
+	}
+
+	for {
+		var fds syscall.FdSet
+		fdSet(&fds, ttyFd)
+		fdSet(&fds, cancelFd)
+
+		err := syscall.Select(maxFd+1, &fds, nil, nil, nil)
+		if err != nil {
+			if err == syscall.EINTR {
+				continue
+			}
+			return 0, false
+		}
+		if fdIsSet(&fds, cancelFd) {
+			return 0, false
+		}
+		if !fdIsSet(&fds, ttyFd) {
+			continue
+		}
+
+		var buf [1]byte
+		n, err := syscall.Read(ttyFd, buf[:])
+		if err != nil || n == 0 {
+			continue
+		}
+		return Key(buf[0]), true
+	}
+}
+
+func (k *darwinKeyReader) Cancel() {
+	k.once.Do(func() {
+		k.cancelW.Write([]byte{0})
+		tcsetattr(k.tty.Fd(), &k.origTermios)
+	})
+}
+
+func tcgetattr(fd uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGETA, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func tcsetattr(fd uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCSETA, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// cfmakeraw mirrors the C library function of the same name: disable
+// canonical mode, echo, signal generation, and input/output translation so
+// reads return raw, unbuffered bytes.
+func cfmakeraw(t *syscall.Termios) {
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB
+	t.Cflag |= syscall.CS8
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+}
+
+func fdSet(set *syscall.FdSet, fd int) {
+	set.Bits[fd/32] |= 1 << uint(fd%32)
+}
+
+func fdIsSet(set *syscall.FdSet, fd int) bool {
+	return set.Bits[fd/32]&(1<<uint(fd%32)) != 0
+}
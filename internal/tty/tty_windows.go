@@ -0,0 +1,142 @@
+//go:build windows
+
+package tty
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	stdInputHandle = ^uint32(10 - 1) // -10, per GetStdHandle's nStdHandle values
+
+	genericRead    = 0x80000000
+	fileShareRead  = 0x00000001
+	fileShareWrite = 0x00000002
+	openExisting   = 3
+
+	keyEventType = 0x0001
+
+	waitObject0 = 0
+	infinite    = 0xFFFFFFFF
+)
+
+var (
+	kernel32                   = syscall.NewLazyDLL("kernel32.dll")
+	procGetStdHandle           = kernel32.NewProc("GetStdHandle")
+	procGetConsoleMode         = kernel32.NewProc("GetConsoleMode")
+	procCreateFileW            = kernel32.NewProc("CreateFileW")
+	procReadConsoleInputW      = kernel32.NewProc("ReadConsoleInputW")
+	procCreateEventW           = kernel32.NewProc("CreateEventW")
+	procSetEvent               = kernel32.NewProc("SetEvent")
+	procWaitForMultipleObjects = kernel32.NewProc("WaitForMultipleObjects")
+	procCloseHandle            = kernel32.NewProc("CloseHandle")
+)
+
+// keyEventRecord mirrors the Win32 KEY_EVENT_RECORD struct.
+type keyEventRecord struct {
+	BKeyDown          int32
+	WRepeatCount      uint16
+	WVirtualKeyCode   uint16
+	WVirtualScanCode  uint16
+	UnicodeChar       uint16
+	DwControlKeyState uint32
+}
+
+// inputRecord mirrors the Win32 INPUT_RECORD struct. KEY_EVENT_RECORD already
+// fills the union, so the trailing padding makes this buffer 8 bytes larger
+// than INPUT_RECORD's real 20 bytes; ReadConsoleInputW writes only the first
+// 20. Go has no union type, and this package only ever reads the
+// KEY_EVENT_RECORD member.
+type inputRecord struct {
+	EventType uint16
+	_         uint16
+	Event     keyEventRecord
+	_         [8]byte
+}
+
+// windowsKeyReader reads raw key events from CONIN$ via ReadConsoleInputW,
+// which (unlike the line-buffered fmt.Scanln loop it replaces) delivers
+// individual keypresses with no Enter required.
+type windowsKeyReader struct {
+	handle      syscall.Handle
+	cancelEvent syscall.Handle
+}
+
+func newKeyReader() KeyReader {
+	if !isTerminal() {
+		return newNoopReader()
+	}
+
+	namePtr, err := syscall.UTF16PtrFromString("CONIN$")
+	if err != nil {
+		return newNoopReader()
+	}
+	h, _, _ := procCreateFileW.Call(
+		uintptr(unsafe.Pointer(namePtr)),
+		uintptr(genericRead),
+		uintptr(fileShareRead|fileShareWrite),
+		0,
+		uintptr(openExisting),
+		0, 0,
+	)
+	if h == 0 || h == uintptr(syscall.InvalidHandle) {
+		return newNoopReader()
+	}
+
+	event, _, _ := procCreateEventW.Call(0, 1, 0, 0) // manual-reset, initially unset
+	if event == 0 {
+		procCloseHandle.Call(h)
+		return newNoopReader()
+	}
+
+	return &windowsKeyReader{handle: syscall.Handle(h), cancelEvent: syscall.Handle(event)}
+}
+
+func (r *windowsKeyReader) ReadKey() (Key, bool) {
+	handles := [2]uintptr{uintptr(r.handle), uintptr(r.cancelEvent)}
+	for {
+		ret, _, _ := procWaitForMultipleObjects.Call(
+			uintptr(len(handles)),
+			uintptr(unsafe.Pointer(&handles[0])),
+			0, // wait for any one handle
+			uintptr(infinite),
+		)
+		if ret == waitObject0+1 {
+			return 0, false // cancelEvent was signaled
+		}
+		if ret != waitObject0 {
+			return 0, false
+		}
+
+		var rec inputRecord
+		var read uint32
+		ret2, _, _ := procReadConsoleInputW.Call(
+			uintptr(r.handle),
+			uintptr(unsafe.Pointer(&rec)),
+			1,
+			uintptr(unsafe.Pointer(&read)),
+		)
+		if ret2 == 0 || read == 0 {
+			continue
+		}
+		if rec.EventType != keyEventType || rec.Event.BKeyDown == 0 || rec.Event.UnicodeChar == 0 {
+			continue // key-up, or a key with no printable character (arrows, modifiers, ...)
+		}
+		return Key(rec.Event.UnicodeChar), true
+	}
+}
+
+func (r *windowsKeyReader) Cancel() {
+	procSetEvent.Call(uintptr(r.cancelEvent))
+}
+
+func isTerminal() bool {
+	h, _, _ := procGetStdHandle.Call(uintptr(stdInputHandle))
+	if h == 0 || h == uintptr(syscall.InvalidHandle) {
+		return false
+	}
+	var mode uint32
+	ret, _, _ := procGetConsoleMode.Call(h, uintptr(unsafe.Pointer(&mode)))
+	return ret != 0
+}
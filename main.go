@@ -1,17 +1,29 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"math/rand"
 	"os"
-	"os/exec"
 	"os/signal"
 	"runtime"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
+
+	"github.com/ternarybob/keep-alive/internal/activity"
+	"github.com/ternarybob/keep-alive/internal/config"
+	"github.com/ternarybob/keep-alive/internal/power"
+	"github.com/ternarybob/keep-alive/internal/service"
+	"github.com/ternarybob/keep-alive/internal/tty"
 )
 
 const (
 	defaultInterval = 30 * time.Second
+	minInterval     = 5 * time.Second
+	intervalStep    = 5 * time.Second
 )
 
 var (
@@ -20,139 +32,502 @@ var (
 	Environment = "dev"
 )
 
-func main() {
-	fmt.Println("Keep-Alive Tool")
-	fmt.Println("===============")
-	fmt.Printf("Version: %s\n", Version)
-	fmt.Printf("Build: %s (%s)\n", BuildTime, Environment)
-	fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
-	fmt.Printf("Simulating user activity every %v to prevent screen lock\n", defaultInterval)
-	fmt.Println("Press Ctrl+C to stop, or type 'q' and press Enter to quit")
-	fmt.Println()
+var serviceConfig = service.Config{
+	Name:        "keep-alive",
+	DisplayName: "Keep-Alive",
+	Description: "Simulates user activity to prevent screen lock and sleep.",
+}
 
-	// Check if running on supported OS and show platform-specific info
-	switch runtime.GOOS {
-	case "darwin":
-		fmt.Println("macOS detected - Using cliclick for mouse simulation")
-		fmt.Println("Note: If mouse movement fails, install cliclick: brew install cliclick")
-	case "windows":
-		fmt.Println("Windows detected - Using PowerShell with Windows API")
-	default:
-		fmt.Printf("Error: This tool supports macOS and Windows only (detected: %s)\n", runtime.GOOS)
-		os.Exit(1)
+// program wires the keep-alive loop into the service.Program lifecycle so it
+// can be driven directly from an interactive console or by the OS's service
+// manager (Windows SCM, launchd).
+type program struct {
+	strategies    []activity.Strategy
+	log           service.Logger
+	resumeKick    bool
+	interval      time.Duration
+	jitter        float64
+	idleThreshold time.Duration
+	schedule      *config.Schedule
+	quietHours    *config.QuietHours
+
+	configPath string
+	profile    string
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+func (p *program) Start() error {
+	p.stopChan = make(chan struct{})
+	p.doneChan = make(chan struct{})
+	go p.run()
+	return nil
+}
+
+func (p *program) Stop() error {
+	close(p.stopChan)
+	<-p.doneChan
+	return nil
+}
+
+// reload re-reads the config file and applies its interval/jitter/strategy/
+// schedule/quiet_hours for the active profile. It's called from run()'s
+// select loop so there's never a data race with the fields it updates.
+func (p *program) reload() {
+	cfg, err := config.Load(p.configPath)
+	if err != nil {
+		p.log.Printf("Warning: config reload failed, keeping previous settings: %v", err)
+		return
 	}
-	fmt.Println()
+	section := cfg.Resolve(p.profile)
 
-	// Create channel to listen for interrupt signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if section.Interval != "" {
+		if d, err := time.ParseDuration(section.Interval); err == nil {
+			p.interval = d
+		} else {
+			p.log.Printf("Warning: config reload: invalid interval %q: %v", section.Interval, err)
+		}
+	}
+	if section.Jitter != "" {
+		if j, err := parseJitter(section.Jitter); err == nil {
+			p.jitter = j
+		} else {
+			p.log.Printf("Warning: config reload: %v", err)
+		}
+	}
+	if section.Strategy != "" {
+		if strategies, err := resolveStrategies(section.Strategy); err == nil {
+			p.strategies = strategies
+		} else {
+			p.log.Printf("Warning: config reload: %v", err)
+		}
+	}
+	schedule, err := config.ParseSchedule(section.Schedule)
+	if err != nil {
+		p.log.Printf("Warning: config reload: %v", err)
+	} else {
+		p.schedule = schedule
+	}
+	quietHours, err := config.ParseQuietHours(section.QuietHours)
+	if err != nil {
+		p.log.Printf("Warning: config reload: %v", err)
+	} else {
+		p.quietHours = quietHours
+	}
+
+	p.log.Printf("Config reloaded")
+}
+
+func (p *program) run() {
+	defer close(p.doneChan)
+
+	keyReader := tty.NewKeyReader()
+	defer keyReader.Cancel()
+
+	keysChan := make(chan tty.Key, 1)
+	go func() {
+		for {
+			key, ok := keyReader.ReadKey()
+			if !ok {
+				return
+			}
+			select {
+			case keysChan <- key:
+			case <-p.stopChan:
+				return
+			}
+		}
+	}()
+
+	var resumeChan <-chan power.Event
+	if p.resumeKick {
+		watcher, err := power.NewWatcher()
+		if err != nil {
+			p.log.Printf("Warning: resume detection unavailable: %v", err)
+		} else {
+			defer watcher.Close()
+			resumeChan = watcher.Events()
+		}
+	}
 
-	// Create channel for keyboard input
-	keyboardChan := make(chan struct{}, 1)
-	go _monitorKeyboard(keyboardChan)
+	var reloadChan <-chan struct{}
+	if p.configPath != "" {
+		watcher, err := config.NewReloadWatcher(p.configPath)
+		if err != nil {
+			p.log.Printf("Warning: config reload watching unavailable: %v", err)
+		} else {
+			defer watcher.Close()
+			reloadChan = watcher.Events()
+		}
+	}
 
-	// Create ticker for periodic activity
-	ticker := time.NewTicker(defaultInterval)
-	defer ticker.Stop()
+	interval := p.interval
+	timer := time.NewTimer(p.jitteredInterval(interval))
+	defer func() { timer.Stop() }()
 
-	fmt.Println("Starting keep-alive simulation...")
+	paused := false
+	strategyIdx := 0
+
+	p.log.Printf("Starting keep-alive simulation...")
 
 	for {
 		select {
-		case <-sigChan:
-			fmt.Println("\nShutdown signal received. Stopping keep-alive tool...")
-			return
-		case <-keyboardChan:
-			fmt.Println("\nKeyboard quit received. Stopping keep-alive tool...")
+		case <-p.stopChan:
+			p.log.Printf("Stopping keep-alive tool...")
 			return
-		case <-ticker.C:
-			_simulateActivity()
+		case key := <-keysChan:
+			switch key {
+			case 'q':
+				p.log.Printf("Keyboard quit received. Stopping keep-alive tool...")
+				return
+			case 'p':
+				if !paused {
+					paused = true
+					p.log.Printf("Paused")
+				}
+			case 'r':
+				if paused {
+					paused = false
+					p.log.Printf("Resumed")
+				}
+			case '+':
+				interval += intervalStep
+				p.log.Printf("Interval increased to %v", interval)
+			case '-':
+				if interval-intervalStep >= minInterval {
+					interval -= intervalStep
+					p.log.Printf("Interval decreased to %v", interval)
+				}
+			}
+		case <-reloadChan:
+			p.reload()
+			interval = p.interval
+			timer.Stop()
+			timer = time.NewTimer(p.jitteredInterval(interval))
+		case ev := <-resumeChan:
+			if ev != power.Resume {
+				continue
+			}
+			// The timer's next fire time was computed before the sleep and
+			// may already be hours in the past; rebuild it and fire a tick
+			// immediately so the first post-wake check succeeds right away
+			// instead of waiting out the stale bucket.
+			p.log.Printf("Resumed from suspend, re-arming timer")
+			timer.Stop()
+			timer = time.NewTimer(p.jitteredInterval(interval))
+			if !paused {
+				p.tick(&strategyIdx)
+			}
+		case <-timer.C:
+			if !paused {
+				p.tick(&strategyIdx)
+			}
+			timer = time.NewTimer(p.jitteredInterval(interval))
 		}
 	}
 }
 
-func _simulateActivity() {
-	var cmd *exec.Cmd
-	var err error
+// jitteredInterval randomizes interval by +/- p.jitter (a fraction, e.g.
+// 0.2 for "20%") so the tick pattern isn't perfectly periodic - monitoring
+// software that flags mechanically regular mouse deltas won't find one.
+func (p *program) jitteredInterval(interval time.Duration) time.Duration {
+	if p.jitter <= 0 {
+		return interval
+	}
+	delta := float64(interval) * p.jitter
+	offset := (rand.Float64()*2 - 1) * delta
+	d := interval + time.Duration(offset)
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
+// tick runs one keep-alive strategy, skipping it entirely if the user was
+// genuinely active recently so real input isn't interfered with. idx tracks
+// which strategy runs next when cycling through more than one.
+func (p *program) tick(idx *int) {
+	now := time.Now()
+	if !p.schedule.Active(now) {
+		return
+	}
+	if p.quietHours.Active(now) {
+		return
+	}
 
-	switch runtime.GOOS {
-	case "darwin":
-		// macOS: Try cliclick first (most reliable), fallback to AppleScript
-		// Check if cliclick is available
-		if _, err := exec.LookPath("cliclick"); err == nil {
-			// Use cliclick - more reliable and doesn't require accessibility permissions
-			cmd = exec.Command("cliclick", "m:+1,+1", "w:10", "m:-1,-1")
-		} else {
-			// Fallback to AppleScript (requires accessibility permissions)
-			script := `
-				tell application "System Events"
-					set currentPos to (get position of mouse)
-					set mouseX to item 1 of currentPos
-					set mouseY to item 2 of currentPos
-					set mouse position to {mouseX + 1, mouseY + 1}
-					delay 0.01
-					set mouse position to {mouseX, mouseY}
-				end tell
-			`
-			cmd = exec.Command("osascript", "-e", script)
+	if p.idleThreshold > 0 {
+		if idle, err := activity.IdleSeconds(); err == nil && idle < p.idleThreshold.Seconds() {
+			p.log.Printf("Skipping tick: user was active %.1fs ago", idle)
+			return
 		}
+	}
 
-	case "windows":
-		// Windows: Use PowerShell with Windows API
-		script := `
-			Add-Type -TypeDefinition '
-				using System;
-				using System.Runtime.InteropServices;
-				public class Win32 {
-					[DllImport("user32.dll")]
-					public static extern bool GetCursorPos(out POINT lpPoint);
-					[DllImport("user32.dll")]
-					public static extern bool SetCursorPos(int x, int y);
-					public struct POINT { public int x; public int y; }
-				}
-			';
-			$pos = New-Object Win32+POINT;
-			[Win32]::GetCursorPos([ref]$pos);
-			[Win32]::SetCursorPos($pos.x + 1, $pos.y + 1);
-			Start-Sleep -Milliseconds 10;
-			[Win32]::SetCursorPos($pos.x, $pos.y);
-		`
-		cmd = exec.Command("powershell", "-Command", script)
+	strat := p.strategies[*idx%len(p.strategies)]
+	*idx++
 
-	default:
-		fmt.Printf("[%s] Error: Unsupported operating system: %s\n", time.Now().Format("15:04:05"), runtime.GOOS)
+	if err := strat.Simulate(context.Background()); err != nil {
+		p.log.Printf("Warning: %s strategy failed: %v", strat.Name(), err)
+		if runtime.GOOS == "darwin" {
+			p.log.Printf("Troubleshooting: Try 'brew install cliclick' or grant accessibility permissions")
+		}
 		return
 	}
+	p.log.Printf("Simulated activity via %s", strat.Name())
+}
 
-	err = cmd.Run()
+// settings is every knob a program needs, after merging explicit flags over
+// the active config profile over keep-alive's built-in defaults.
+type settings struct {
+	interval      time.Duration
+	resumeKick    bool
+	strategies    []activity.Strategy
+	jitter        float64
+	idleThreshold time.Duration
+	schedule      *config.Schedule
+	quietHours    *config.QuietHours
+	configPath    string
+	profile       string
+}
 
+func main() {
+	serviceCmd := flag.String("service", "", "Control the background service: install, uninstall, start, stop, or run")
+	resumeKick := flag.Bool("resume-kick", true, "Immediately fire a keep-alive tick on wake from suspend")
+	interval := flag.Duration("interval", defaultInterval, "How often to simulate activity")
+	strategyName := flag.String("strategy", "auto", `Activity strategy to use, or "auto" to cycle through every available strategy`)
+	jitterFlag := flag.String("jitter", "20%", `Randomize the tick interval by this fraction, e.g. "20%"`)
+	idleThreshold := flag.Duration("idle-threshold", 2*time.Second, "Skip a tick if real user input was seen within this long")
+	scheduleFlag := flag.String("schedule", "", `Only run during this window, e.g. "Mon-Fri 09:00-17:00" (default: always)`)
+	quietHoursFlag := flag.String("quiet-hours", "", `Skip ticks during this daily window, e.g. "22:00-07:00"`)
+	configPath := flag.String("config", config.DefaultPath(), "Path to config.toml")
+	profile := flag.String("profile", "", "Named [profiles.<name>] section to apply from the config file")
+	flag.Parse()
+
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	cfg, err := config.Load(*configPath)
 	if err != nil {
-		timestamp := time.Now().Format("15:04:05")
-		if runtime.GOOS == "darwin" {
-			fmt.Printf("[%s] Warning: Failed to simulate mouse activity: %v\n", timestamp, err)
-			fmt.Printf("[%s] Troubleshooting: Try 'brew install cliclick' or grant accessibility permissions\n", timestamp)
-		} else {
-			fmt.Printf("[%s] Warning: Failed to simulate mouse activity: %v\n", timestamp, err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, statErr := os.Stat(*configPath); os.IsNotExist(statErr) {
+		fmt.Fprintf(os.Stderr, "No config file at %s, using defaults\n", *configPath)
+	}
+	section := cfg.Resolve(*profile)
+
+	if section.Interval != "" && !explicit["interval"] {
+		d, err := time.ParseDuration(section.Interval)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: config: invalid interval %q: %v\n", section.Interval, err)
+			os.Exit(1)
 		}
-	} else {
-		fmt.Printf("[%s] Simulated mouse activity\n", time.Now().Format("15:04:05"))
+		*interval = d
+	}
+	if section.Jitter != "" && !explicit["jitter"] {
+		*jitterFlag = section.Jitter
+	}
+	if section.Strategy != "" && !explicit["strategy"] {
+		*strategyName = section.Strategy
+	}
+	if section.Schedule != "" && !explicit["schedule"] {
+		*scheduleFlag = section.Schedule
+	}
+	if section.QuietHours != "" && !explicit["quiet-hours"] {
+		*quietHoursFlag = section.QuietHours
+	}
+
+	jitter, err := parseJitter(*jitterFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	strategies, err := resolveStrategies(*strategyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	schedule, err := config.ParseSchedule(*scheduleFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	quietHours, err := config.ParseQuietHours(*quietHoursFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	st := settings{
+		interval:      *interval,
+		resumeKick:    *resumeKick,
+		strategies:    strategies,
+		jitter:        jitter,
+		idleThreshold: *idleThreshold,
+		schedule:      schedule,
+		quietHours:    quietHours,
+		configPath:    *configPath,
+		profile:       *profile,
+	}
+
+	mgr := service.New(serviceConfig)
+
+	if *serviceCmd != "" {
+		if err := runServiceCommand(mgr, *serviceCmd, st); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	interactive := service.Interactive()
+	if interactive {
+		printBanner(st)
+	}
+
+	p := newProgram(st, service.NewLogger(serviceConfig, interactive))
+
+	if !interactive {
+		// Launched by the OS's service manager: hand control over to it and
+		// block for the life of the service.
+		if err := mgr.Run(p); err != nil {
+			p.log.Printf("Service exited with error: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := p.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-sigChan:
+		fmt.Println("\nShutdown signal received. Stopping keep-alive tool...")
+		p.Stop()
+	case <-p.doneChan:
+		// run() returned on its own, e.g. after the 'q' hotkey.
 	}
 }
 
-// _monitorKeyboard monitors for 'q' input to quit the program
-func _monitorKeyboard(keyboardChan chan struct{}) {
-	for {
-		var input string
-		// Read line from stdin
-		if _, err := fmt.Scanln(&input); err != nil {
-			// If stdin is closed or there's an error, continue
-			continue
+// newProgram builds a program from merged settings.
+func newProgram(st settings, log service.Logger) *program {
+	return &program{
+		strategies:    st.strategies,
+		log:           log,
+		resumeKick:    st.resumeKick,
+		interval:      st.interval,
+		jitter:        st.jitter,
+		idleThreshold: st.idleThreshold,
+		schedule:      st.schedule,
+		quietHours:    st.quietHours,
+		configPath:    st.configPath,
+		profile:       st.profile,
+	}
+}
+
+// resolveStrategies picks the strategies tick() cycles through: either the
+// single one named, or every available strategy when name is "auto".
+func resolveStrategies(name string) ([]activity.Strategy, error) {
+	all := activity.Strategies()
+
+	if name == "auto" || name == "" {
+		var avail []activity.Strategy
+		for _, s := range all {
+			if s.Available() {
+				avail = append(avail, s)
+			}
 		}
-		
-		// Check for quit commands
-		if input == "q" || input == "quit" || input == "exit" {
-			keyboardChan <- struct{}{}
-			return
+		if len(avail) == 0 {
+			return nil, fmt.Errorf("no activity strategies are available on this platform")
+		}
+		return avail, nil
+	}
+
+	s := activity.ByName(name)
+	if s == nil {
+		return nil, fmt.Errorf("unknown --strategy %q", name)
+	}
+	if !s.Available() {
+		return nil, fmt.Errorf("strategy %q is not available in this environment", name)
+	}
+	return []activity.Strategy{s}, nil
+}
+
+// parseJitter parses a --jitter value like "20%" or "0.2" into a fraction.
+func parseJitter(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if trimmed := strings.TrimSuffix(s, "%"); trimmed != s {
+		v, err := strconv.ParseFloat(strings.TrimSpace(trimmed), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --jitter %q: %w", s, err)
 		}
+		return v / 100, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --jitter %q: %w", s, err)
+	}
+	return v, nil
+}
+
+func runServiceCommand(mgr service.Manager, cmd string, st settings) error {
+	switch cmd {
+	case "install":
+		return mgr.Install()
+	case "uninstall":
+		return mgr.Uninstall()
+	case "start":
+		return mgr.Start()
+	case "stop":
+		return mgr.Stop()
+	case "run":
+		p := newProgram(st, service.NewLogger(serviceConfig, service.Interactive()))
+		return mgr.Run(p)
+	default:
+		return fmt.Errorf("unknown --service command %q (want install, uninstall, start, stop, or run)", cmd)
+	}
+}
+
+func printBanner(st settings) {
+	fmt.Println("Keep-Alive Tool")
+	fmt.Println("===============")
+	fmt.Printf("Version: %s\n", Version)
+	fmt.Printf("Build: %s (%s)\n", BuildTime, Environment)
+	fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("Simulating user activity every %v to prevent screen lock\n", st.interval)
+	fmt.Println("Press Ctrl+C, or press 'q' to quit, 'p'/'r' to pause/resume, '+'/'-' to adjust the interval")
+	fmt.Println()
+
+	switch runtime.GOOS {
+	case "darwin":
+		fmt.Println("macOS detected - Using cliclick for mouse simulation")
+		fmt.Println("Note: If mouse movement fails, install cliclick: brew install cliclick")
+	case "windows":
+		fmt.Println("Windows detected - Using native SendInput via user32.dll")
+	default:
+		fmt.Printf("Error: This tool supports macOS and Windows only (detected: %s)\n", runtime.GOOS)
+		os.Exit(1)
 	}
+
+	names := make([]string, len(st.strategies))
+	for i, s := range st.strategies {
+		names[i] = s.Name()
+	}
+	fmt.Printf("Strategies in use: %s\n", strings.Join(names, ", "))
+	if st.profile != "" {
+		fmt.Printf("Profile: %s\n", st.profile)
+	}
+	if st.schedule != nil {
+		fmt.Println("Active only during the configured schedule window")
+	}
+	fmt.Println()
 }